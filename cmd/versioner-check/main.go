@@ -0,0 +1,73 @@
+// Command versioner-check runs Versioner.Validate against a database and exits non-zero on mismatch. It is
+// meant to be run as a fail-fast schema check at process startup or as a deployment pipeline step.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+
+	_ "github.com/ClickHouse/clickhouse-go/v2"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	_ "github.com/microsoft/go-mssqldb"
+
+	"github.com/normegil/godatabaseversioner"
+	"github.com/normegil/godatabaseversioner/dialectquery"
+)
+
+func main() {
+	if err := run(); nil != err {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	driver := flag.String("driver", "postgres", "database/sql driver name to open the DSN with")
+	dialectName := flag.String("dialect", "postgres", "dialect of the version table: postgres, mysql, sqlite3, sqlserver or clickhouse")
+	dsn := flag.String("dsn", "", "data source name used to connect to the database")
+	expected := flag.Int("expected", 0, "version the database is expected to be at")
+	flag.Parse()
+
+	dialect, err := dialectFor(*dialectName)
+	if nil != err {
+		return err
+	}
+
+	db, err := sql.Open(*driver, *dsn)
+	if nil != err {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	versioner := godatabaseversioner.Versioner{
+		Applier:  godatabaseversioner.GenericVersionApplier{DB: db, Dialect: dialect},
+		Listener: godatabaseversioner.NewZerologListener(),
+	}
+
+	if err := versioner.Validate(context.Background(), *expected); nil != err {
+		return fmt.Errorf("schema check failed: %w", err)
+	}
+	return nil
+}
+
+func dialectFor(name string) (dialectquery.Dialect, error) {
+	switch name {
+	case "postgres":
+		return dialectquery.Postgres{}, nil
+	case "mysql":
+		return dialectquery.MySQL{}, nil
+	case "sqlite3":
+		return dialectquery.SQLite3{}, nil
+	case "sqlserver":
+		return dialectquery.SQLServer{}, nil
+	case "clickhouse":
+		return dialectquery.ClickHouse{}, nil
+	default:
+		return nil, fmt.Errorf("unknown dialect '%s'", name)
+	}
+}
@@ -1,22 +1,45 @@
 package godatabaseversioner
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"github.com/normegil/postgres"
-	"strings"
+
+	"github.com/normegil/godatabaseversioner/dialectquery"
 )
 
-// PostgresVersionApplier is a version applier that will work with a postgres database.
-type PostgresVersionApplier struct {
-	DB *sql.DB
+// GenericVersionApplier is a VersionApplier that derives all its statements from a Dialect, so the same
+// implementation can be reused across database engines instead of writing one applier per driver.
+type GenericVersionApplier struct {
+	DB      *sql.DB
+	Dialect dialectquery.Dialect
+	// TableName is the name of the table holding the version history. Defaults to "version" if empty.
+	TableName string
 }
 
-func (a PostgresVersionApplier) CurrentVersion() (int, error) {
-	row := a.DB.QueryRow("SELECT version FROM version ORDER BY modificationTime DESC LIMIT 1")
+func (a GenericVersionApplier) tableName() string {
+	if "" == a.TableName {
+		return "version"
+	}
+	return a.TableName
+}
+
+func (a GenericVersionApplier) CurrentVersion(ctx context.Context) (int, error) {
+	tableName := a.tableName()
+
+	var exists bool
+	if err := a.DB.QueryRowContext(ctx, a.Dialect.TableExists(tableName)).Scan(&exists); nil != err {
+		return -1, fmt.Errorf("checking existence of table '%s': %w", tableName, err)
+	}
+	if !exists {
+		return -1, nil
+	}
+
+	row := a.DB.QueryRowContext(ctx, a.Dialect.CurrentVersion(tableName))
 	var version int
 	if err := row.Scan(&version); err != nil {
-		if a.errIsTableNotExist(err) {
+		if errors.Is(err, sql.ErrNoRows) {
 			return -1, nil
 		}
 		return -1, fmt.Errorf("could not get current version: %w", err)
@@ -24,56 +47,132 @@ func (a PostgresVersionApplier) CurrentVersion() (int, error) {
 	return version, nil
 }
 
-func (a PostgresVersionApplier) SyncVersion(versionNb int) error {
-	if _, err := a.DB.Exec(`INSERT INTO 
-    		version(id, version, modificationTime)
-    		VALUES (gen_random_uuid(), $1, now())`, versionNb); nil != err {
-		return fmt.Errorf("could not insert version %d: %w", versionNb, err)
+func (a GenericVersionApplier) RecordUpgrade(ctx context.Context, versionNb int) error {
+	if _, err := a.DB.ExecContext(ctx, a.Dialect.InsertVersion(a.tableName()), versionNb); nil != err {
+		return fmt.Errorf("could not record upgrade to version %d: %w", versionNb, err)
 	}
 	return nil
 }
 
-func (d PostgresVersionApplier) errIsTableNotExist(err error) bool {
-	return strings.Contains(err.Error(), "not exist")
+func (a GenericVersionApplier) RecordRollback(ctx context.Context, versionNb int) error {
+	if _, err := a.DB.ExecContext(ctx, a.Dialect.DeleteVersion(a.tableName()), versionNb); nil != err {
+		return fmt.Errorf("could not record rollback of version %d: %w", versionNb, err)
+	}
+	return nil
 }
 
-// PostgresVersioning is a schema version to install. It will create a versioning table which will hold version number and modification time for each version change
-type PostgresVersioning struct {
+// SyncVersion records versionNb as the new current version.
+//
+// Deprecated: use RecordUpgrade instead.
+func (a GenericVersionApplier) SyncVersion(ctx context.Context, versionNb int) error {
+	return a.RecordUpgrade(ctx, versionNb)
+}
+
+// PostgresVersionApplier is a version applier that will work with a postgres database.
+//
+// Deprecated: use GenericVersionApplier with dialectquery.Postgres{} instead.
+type PostgresVersionApplier struct {
 	DB *sql.DB
+}
+
+func (a PostgresVersionApplier) applier() GenericVersionApplier {
+	return GenericVersionApplier{DB: a.DB, Dialect: dialectquery.Postgres{}}
+}
+
+func (a PostgresVersionApplier) CurrentVersion(ctx context.Context) (int, error) {
+	return a.applier().CurrentVersion(ctx)
+}
+
+func (a PostgresVersionApplier) RecordUpgrade(ctx context.Context, versionNb int) error {
+	return a.applier().RecordUpgrade(ctx, versionNb)
+}
+
+func (a PostgresVersionApplier) RecordRollback(ctx context.Context, versionNb int) error {
+	return a.applier().RecordRollback(ctx, versionNb)
+}
+
+// SyncVersion records versionNb as the new current version.
+//
+// Deprecated: use RecordUpgrade instead.
+func (a PostgresVersionApplier) SyncVersion(ctx context.Context, versionNb int) error {
+	return a.applier().SyncVersion(ctx, versionNb)
+}
+
+// VersioningTable is a schema version to install. It will create a versioning table which will hold version
+// number and modification time for each version change, using the statements provided by Dialect.
+type VersioningTable struct {
+	DB      *sql.DB
+	Dialect dialectquery.Dialect
 	// VersionNumber is strongly suggested to be 0, to be the first modification to do to your database
 	VersionNumber int
+	// TableName is the name of the table holding the version history. Defaults to "version" if empty.
+	TableName string
 }
 
-func (v PostgresVersioning) Number() int {
+func (v VersioningTable) tableName() string {
+	if "" == v.TableName {
+		return "version"
+	}
+	return v.TableName
+}
+
+func (v VersioningTable) Number() int {
 	return v.VersionNumber
 }
 
-func (v PostgresVersioning) Upgrade() error {
-	row := v.DB.QueryRow(`SELECT pg_catalog.pg_get_userbyid(d.datdba) as "Owner" FROM pg_catalog.pg_database d WHERE d.datname = current_database();`)
-	var owner string
-	if err := row.Scan(&owner); nil != err {
-		return fmt.Errorf("load database owner: %w", err)
+func (v VersioningTable) Upgrade(ctx context.Context) error {
+	tableName := v.tableName()
+
+	var exists bool
+	if err := v.DB.QueryRowContext(ctx, v.Dialect.TableExists(tableName)).Scan(&exists); nil != err {
+		return fmt.Errorf("checking existence of table '%s': %w", tableName, err)
+	}
+	if exists {
+		return nil
 	}
 
-	tableExistence := `SELECT EXISTS ( SELECT 1 FROM information_schema.tables WHERE table_name = '%s');`
-	tableSetOwner := `ALTER TABLE %s OWNER TO $1;`
-
-	versionTableName := "version"
-	err := postgres.CreateTable(v.DB, postgres.TableInfos{
-		Queries: map[string]string{
-			"Table-Existence": fmt.Sprintf(tableExistence, versionTableName),
-			"Table-Create":    `CREATE TABLE version (id uuid primary key, version integer, modificationTime timestamp)`,
-			"Table-Set-Owner": fmt.Sprintf(tableSetOwner, versionTableName),
-		},
-		Owner: owner,
-	})
-	if err != nil {
-		return fmt.Errorf("creating table '%s': %w", versionTableName, err)
+	if _, err := v.DB.ExecContext(ctx, v.Dialect.CreateVersionTable(tableName)); nil != err {
+		return fmt.Errorf("creating table '%s': %w", tableName, err)
+	}
+
+	if ownerQuery := v.Dialect.CurrentOwnerQuery(); "" != ownerQuery {
+		var owner string
+		if err := v.DB.QueryRowContext(ctx, ownerQuery).Scan(&owner); nil != err {
+			return fmt.Errorf("load database owner: %w", err)
+		}
+		if _, err := v.DB.ExecContext(ctx, v.Dialect.SetOwner(tableName, owner)); nil != err {
+			return fmt.Errorf("setting owner of table '%s': %w", tableName, err)
+		}
 	}
 
 	return nil
 }
 
-func (v PostgresVersioning) Rollback() error {
+func (v VersioningTable) Rollback(_ context.Context) error {
 	return fmt.Errorf("cannot rollback this change")
 }
+
+// PostgresVersioning is a schema version to install. It will create a versioning table which will hold version number and modification time for each version change
+//
+// Deprecated: use VersioningTable with dialectquery.Postgres{} instead.
+type PostgresVersioning struct {
+	DB *sql.DB
+	// VersionNumber is strongly suggested to be 0, to be the first modification to do to your database
+	VersionNumber int
+}
+
+func (v PostgresVersioning) table() VersioningTable {
+	return VersioningTable{DB: v.DB, Dialect: dialectquery.Postgres{}, VersionNumber: v.VersionNumber}
+}
+
+func (v PostgresVersioning) Number() int {
+	return v.table().Number()
+}
+
+func (v PostgresVersioning) Upgrade(ctx context.Context) error {
+	return v.table().Upgrade(ctx)
+}
+
+func (v PostgresVersioning) Rollback(ctx context.Context) error {
+	return v.table().Rollback(ctx)
+}
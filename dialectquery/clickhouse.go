@@ -0,0 +1,34 @@
+package dialectquery
+
+import "fmt"
+
+// ClickHouse is the Dialect implementation for ClickHouse.
+type ClickHouse struct{}
+
+func (ClickHouse) TableExists(tableName string) string {
+	return fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM system.tables WHERE database = currentDatabase() AND name = '%s')`, tableName)
+}
+
+func (ClickHouse) CreateVersionTable(tableName string) string {
+	return fmt.Sprintf(`CREATE TABLE %s (id UUID, version Int32, modificationTime DateTime64(6)) ENGINE = MergeTree ORDER BY modificationTime`, tableName)
+}
+
+func (ClickHouse) CurrentOwnerQuery() string {
+	return ""
+}
+
+func (ClickHouse) SetOwner(string, string) string {
+	return ""
+}
+
+func (ClickHouse) InsertVersion(tableName string) string {
+	return fmt.Sprintf(`INSERT INTO %s(id, version, modificationTime) VALUES (generateUUIDv4(), ?, now64(6))`, tableName)
+}
+
+func (ClickHouse) CurrentVersion(tableName string) string {
+	return fmt.Sprintf(`SELECT version FROM %s ORDER BY modificationTime DESC LIMIT 1`, tableName)
+}
+
+func (ClickHouse) DeleteVersion(tableName string) string {
+	return fmt.Sprintf(`ALTER TABLE %s DELETE WHERE version = ?`, tableName)
+}
@@ -0,0 +1,25 @@
+// Package dialectquery provides the per-database-engine SQL statements used by GenericVersionApplier and
+// VersioningTable, so the rest of the module can stay free of driver-specific SQL.
+package dialectquery
+
+// Dialect produces the SQL statements needed to manage the version table for a specific database engine.
+type Dialect interface {
+	// TableExists returns a query reporting, as a single boolean column, whether the version table already exists.
+	TableExists(tableName string) string
+	// CreateVersionTable returns the query used to create the version table.
+	CreateVersionTable(tableName string) string
+	// CurrentOwnerQuery returns the query used to read the current database owner, or an empty string if the
+	// engine has no notion of table ownership.
+	CurrentOwnerQuery() string
+	// SetOwner returns the query used to make owner the owner of tableName, or an empty string if the engine has
+	// no notion of table ownership.
+	SetOwner(tableName, owner string) string
+	// InsertVersion returns the query used to record a newly applied version. The version number is passed as
+	// the query's first argument.
+	InsertVersion(tableName string) string
+	// CurrentVersion returns the query used to read the most recently applied version.
+	CurrentVersion(tableName string) string
+	// DeleteVersion returns the query used to remove a version row, typically after a rollback. The version
+	// number is passed as the query's first argument.
+	DeleteVersion(tableName string) string
+}
@@ -0,0 +1,34 @@
+package dialectquery
+
+import "fmt"
+
+// MySQL is the Dialect implementation for MySQL/MariaDB.
+type MySQL struct{}
+
+func (MySQL) TableExists(tableName string) string {
+	return fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = '%s')`, tableName)
+}
+
+func (MySQL) CreateVersionTable(tableName string) string {
+	return fmt.Sprintf(`CREATE TABLE %s (id CHAR(36) PRIMARY KEY, version INTEGER, modificationTime DATETIME(6))`, tableName)
+}
+
+func (MySQL) CurrentOwnerQuery() string {
+	return ""
+}
+
+func (MySQL) SetOwner(string, string) string {
+	return ""
+}
+
+func (MySQL) InsertVersion(tableName string) string {
+	return fmt.Sprintf(`INSERT INTO %s(id, version, modificationTime) VALUES (UUID(), ?, NOW(6))`, tableName)
+}
+
+func (MySQL) CurrentVersion(tableName string) string {
+	return fmt.Sprintf(`SELECT version FROM %s ORDER BY modificationTime DESC LIMIT 1`, tableName)
+}
+
+func (MySQL) DeleteVersion(tableName string) string {
+	return fmt.Sprintf(`DELETE FROM %s WHERE version = ?`, tableName)
+}
@@ -0,0 +1,34 @@
+package dialectquery
+
+import "fmt"
+
+// Postgres is the Dialect implementation for PostgreSQL.
+type Postgres struct{}
+
+func (Postgres) TableExists(tableName string) string {
+	return fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = '%s')`, tableName)
+}
+
+func (Postgres) CreateVersionTable(tableName string) string {
+	return fmt.Sprintf(`CREATE TABLE %s (id uuid primary key, version integer, modificationTime timestamp)`, tableName)
+}
+
+func (Postgres) CurrentOwnerQuery() string {
+	return `SELECT pg_catalog.pg_get_userbyid(d.datdba) as "Owner" FROM pg_catalog.pg_database d WHERE d.datname = current_database()`
+}
+
+func (Postgres) SetOwner(tableName, owner string) string {
+	return fmt.Sprintf(`ALTER TABLE %s OWNER TO %s`, tableName, owner)
+}
+
+func (Postgres) InsertVersion(tableName string) string {
+	return fmt.Sprintf(`INSERT INTO %s(id, version, modificationTime) VALUES (gen_random_uuid(), $1, now())`, tableName)
+}
+
+func (Postgres) CurrentVersion(tableName string) string {
+	return fmt.Sprintf(`SELECT version FROM %s ORDER BY modificationTime DESC LIMIT 1`, tableName)
+}
+
+func (Postgres) DeleteVersion(tableName string) string {
+	return fmt.Sprintf(`DELETE FROM %s WHERE version = $1`, tableName)
+}
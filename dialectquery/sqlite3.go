@@ -0,0 +1,34 @@
+package dialectquery
+
+import "fmt"
+
+// SQLite3 is the Dialect implementation for SQLite.
+type SQLite3 struct{}
+
+func (SQLite3) TableExists(tableName string) string {
+	return fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = '%s')`, tableName)
+}
+
+func (SQLite3) CreateVersionTable(tableName string) string {
+	return fmt.Sprintf(`CREATE TABLE %s (id TEXT PRIMARY KEY, version INTEGER, modificationTime DATETIME)`, tableName)
+}
+
+func (SQLite3) CurrentOwnerQuery() string {
+	return ""
+}
+
+func (SQLite3) SetOwner(string, string) string {
+	return ""
+}
+
+func (SQLite3) InsertVersion(tableName string) string {
+	return fmt.Sprintf(`INSERT INTO %s(id, version, modificationTime) VALUES (lower(hex(randomblob(16))), ?, CURRENT_TIMESTAMP)`, tableName)
+}
+
+func (SQLite3) CurrentVersion(tableName string) string {
+	return fmt.Sprintf(`SELECT version FROM %s ORDER BY modificationTime DESC, rowid DESC LIMIT 1`, tableName)
+}
+
+func (SQLite3) DeleteVersion(tableName string) string {
+	return fmt.Sprintf(`DELETE FROM %s WHERE version = ?`, tableName)
+}
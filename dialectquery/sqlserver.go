@@ -0,0 +1,34 @@
+package dialectquery
+
+import "fmt"
+
+// SQLServer is the Dialect implementation for Microsoft SQL Server.
+type SQLServer struct{}
+
+func (SQLServer) TableExists(tableName string) string {
+	return fmt.Sprintf(`SELECT CAST(CASE WHEN EXISTS (SELECT 1 FROM sys.tables WHERE name = '%s') THEN 1 ELSE 0 END AS BIT)`, tableName)
+}
+
+func (SQLServer) CreateVersionTable(tableName string) string {
+	return fmt.Sprintf(`CREATE TABLE %s (id UNIQUEIDENTIFIER PRIMARY KEY, version INT, modificationTime DATETIME2)`, tableName)
+}
+
+func (SQLServer) CurrentOwnerQuery() string {
+	return ""
+}
+
+func (SQLServer) SetOwner(string, string) string {
+	return ""
+}
+
+func (SQLServer) InsertVersion(tableName string) string {
+	return fmt.Sprintf(`INSERT INTO %s(id, version, modificationTime) VALUES (NEWID(), @p1, SYSUTCDATETIME())`, tableName)
+}
+
+func (SQLServer) CurrentVersion(tableName string) string {
+	return fmt.Sprintf(`SELECT TOP 1 version FROM %s ORDER BY modificationTime DESC`, tableName)
+}
+
+func (SQLServer) DeleteVersion(tableName string) string {
+	return fmt.Sprintf(`DELETE FROM %s WHERE version = @p1`, tableName)
+}
@@ -0,0 +1,121 @@
+//go:build integration
+
+package godatabaseversioner_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	godatabaseversioner "github.com/normegil/godatabaseversioner"
+	"github.com/normegil/godatabaseversioner/dialectquery"
+)
+
+// sqlVersion is a Version that runs raw SQL for its upgrade/rollback scripts, standing in for the statements a
+// real migration would run against the container's schema.
+type sqlVersion struct {
+	number      int
+	upgradeSQL  string
+	rollbackSQL string
+	db          *sql.DB
+}
+
+func (v sqlVersion) Number() int { return v.number }
+
+func (v sqlVersion) Upgrade(ctx context.Context) error {
+	_, err := v.db.ExecContext(ctx, v.upgradeSQL)
+	return err
+}
+
+func (v sqlVersion) Rollback(ctx context.Context) error {
+	_, err := v.db.ExecContext(ctx, v.rollbackSQL)
+	return err
+}
+
+// TestVersioner_SyncContext_Postgres exercises GenericVersionApplier against a real postgres container, driving
+// an upgrade to version 2 and then a downgrade back to version 0, and checking CurrentVersion after each step.
+// This is the behavioral coverage the downgrade/record-rollback lifecycle needs and that fakes alone can't give:
+// it depends on the version table actually existing (or not) in postgres, and on postgres's own row ordering.
+func TestVersioner_SyncContext_Postgres(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:16-alpine"),
+		postgres.WithDatabase("versioner"),
+		postgres.WithUsername("versioner"),
+		postgres.WithPassword("versioner"),
+		testcontainers.WithWaitStrategy(wait.ForLog("database system is ready to accept connections").WithOccurrence(2)),
+	)
+	if nil != err {
+		t.Fatalf("starting postgres container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); nil != err {
+			t.Errorf("terminating postgres container: %v", err)
+		}
+	})
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if nil != err {
+		t.Fatalf("building connection string: %v", err)
+	}
+	db, err := sql.Open("postgres", dsn)
+	if nil != err {
+		t.Fatalf("opening database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	applier := godatabaseversioner.GenericVersionApplier{DB: db, Dialect: dialectquery.Postgres{}}
+
+	current, err := applier.CurrentVersion(ctx)
+	if nil != err {
+		t.Fatalf("CurrentVersion on fresh database: %v", err)
+	}
+	if -1 != current {
+		t.Fatalf("CurrentVersion on fresh database: got %d, want -1", current)
+	}
+
+	versioner := godatabaseversioner.Versioner{
+		Applier: applier,
+		Versions: []godatabaseversioner.Version{
+			godatabaseversioner.VersioningTable{DB: db, Dialect: dialectquery.Postgres{}, VersionNumber: 0},
+			sqlVersion{number: 1, db: db, upgradeSQL: `ALTER TABLE version ADD COLUMN note text`, rollbackSQL: `ALTER TABLE version DROP COLUMN note`},
+			sqlVersion{number: 2, db: db, upgradeSQL: `CREATE TABLE widgets (id serial primary key)`, rollbackSQL: `DROP TABLE widgets`},
+		},
+		Listener: godatabaseversioner.NoOpListener{},
+	}
+
+	if err := versioner.SyncContext(ctx, 2); nil != err {
+		t.Fatalf("upgrade to version 2: %v", err)
+	}
+	current, err = versioner.CurrentVersionContext(ctx)
+	if nil != err {
+		t.Fatalf("CurrentVersion after upgrade: %v", err)
+	}
+	if 2 != current {
+		t.Fatalf("CurrentVersion after upgrade: got %d, want 2", current)
+	}
+	var widgetCount int
+	if err := db.QueryRowContext(ctx, `SELECT count(*) FROM widgets`).Scan(&widgetCount); nil != err {
+		t.Fatalf("widgets table missing after upgrade: %v", err)
+	}
+
+	if err := versioner.SyncContext(ctx, 0); nil != err {
+		t.Fatalf("downgrade to version 0: %v", err)
+	}
+	current, err = versioner.CurrentVersionContext(ctx)
+	if nil != err {
+		t.Fatalf("CurrentVersion after downgrade: %v", err)
+	}
+	if 0 != current {
+		t.Fatalf("CurrentVersion after downgrade: got %d, want 0", current)
+	}
+	if err := db.QueryRowContext(ctx, `SELECT count(*) FROM widgets`).Scan(&widgetCount); nil == err {
+		t.Fatalf("widgets table still present after downgrade")
+	}
+}
@@ -1,7 +1,9 @@
 package godatabaseversioner
 
 import (
+	"context"
 	"database/sql"
+
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -10,7 +12,7 @@ import (
 type NoOpListener struct {
 }
 
-func (l NoOpListener) On(_ Event) error {
+func (l NoOpListener) On(_ context.Context, _ Event) error {
 	return nil
 }
 
@@ -25,9 +27,9 @@ type EventBroadcastListener struct {
 	Listeners []Listener
 }
 
-func (l EventBroadcastListener) On(event Event) error {
+func (l EventBroadcastListener) On(ctx context.Context, event Event) error {
 	for _, listener := range l.Listeners {
-		if err := listener.On(event); nil != err {
+		if err := listener.On(ctx, event); nil != err {
 			return err
 		}
 	}
@@ -39,7 +41,7 @@ func NewZerologListener() *ZerologListener {
 	return &ZerologListener{log.Debug()}
 }
 
-func (l ZerologListener) On(event Event) error {
+func (l ZerologListener) On(_ context.Context, event Event) error {
 	switch event.Type {
 	case EventBeforeSync:
 		l.Logger.Msg("starting syncing process")
@@ -49,21 +51,34 @@ func (l ZerologListener) On(event Event) error {
 		l.Logger.Int("version", event.Version.Number()).Msg("version applied")
 	case EventAfterSync:
 		l.Logger.Msg("end of syncing process")
+	case EventValidate:
+		if nil != event.Error {
+			l.Logger.Err(event.Error).Msg("schema validation failed")
+		} else {
+			l.Logger.Msg("schema validation passed")
+		}
 	}
 	return nil
 }
 
-// TransactionalChangesListener will open and commit a transaction during each version application
+// TransactionalChangesListener will open and commit a transaction during each version application.
+//
+// Must be used as a pointer (&TransactionalChangesListener{...}): On stores the open transaction on the
+// listener between EventBeforeChange and EventAfterChange/EventErrorDuringChange, and a value receiver would
+// write that to a discarded copy, leaving currentTransaction nil and panicking on Commit/Rollback.
 type TransactionalChangesListener struct {
-	DB                 *sql.DB
+	DB *sql.DB
+	// Options configures the isolation level (and read-only flag) used for each per-version transaction. A nil
+	// value lets the driver pick its default isolation level.
+	Options            *sql.TxOptions
 	currentTransaction *sql.Tx
 }
 
-func (l TransactionalChangesListener) On(event Event) error {
+func (l *TransactionalChangesListener) On(ctx context.Context, event Event) error {
 	var err error
 	switch event.Type {
 	case EventBeforeChange:
-		l.currentTransaction, err = l.DB.Begin()
+		l.currentTransaction, err = l.DB.BeginTx(ctx, l.Options)
 		if err != nil {
 			return err
 		}
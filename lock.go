@@ -0,0 +1,303 @@
+package godatabaseversioner
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+)
+
+// ErrAlreadyUpToDate is returned by a lock listener's On method, from EventStart, when the lock listener was
+// configured with a TargetVersion and the re-checked version already matches it once the lock is acquired. Sync
+// treats any listener error as fatal, so callers racing UpgradeToLastContext across instances should use
+// errors.Is(err, ErrAlreadyUpToDate) to treat a losing race as a successful no-op rather than a failure.
+var ErrAlreadyUpToDate = errors.New("database already at the requested version")
+
+// defaultLockNamespace is hashed into a lock key when a lock listener is created without an explicit Key.
+const defaultLockNamespace = "godatabaseversioner"
+
+func hashNamespace(namespace string) int64 {
+	if "" == namespace {
+		namespace = defaultLockNamespace
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(namespace))
+	return int64(h.Sum64())
+}
+
+// PostgresAdvisoryLockListener serializes concurrent syncs of the same database using a postgres session-level
+// advisory lock (pg_advisory_lock), so that multiple application instances starting at the same time don't race
+// to apply the same migrations. It acquires the lock on EventStart and releases it on EventEnd/EventError/
+// EventErrorDuringChange, so a migration that fails mid-change still gives up the lock instead of leaking it
+// and blocking every other deployer.
+//
+// pg_advisory_lock is tied to the database session that took it, so acquire and release must happen on the same
+// *sql.Conn rather than through the *sql.DB pool, or the unlock can land on a different pooled connection and
+// never actually release the lock. The listener checks out a dedicated connection on EventStart and returns it
+// to the pool once the lock is released.
+//
+// Since the winner of the race will have already synced the version table by the time a losing process acquires
+// the lock, On checks the re-read version against TargetVersion once the lock is granted and, on a match,
+// releases the lock and returns ErrAlreadyUpToDate instead of letting Sync attempt a now-redundant migration.
+type PostgresAdvisoryLockListener struct {
+	DB *sql.DB
+	// Applier is used to re-read the current version once the lock is acquired, so a process that loses the
+	// race can detect that another instance already finished.
+	Applier VersionApplier
+	// TargetVersion is the version the caller is about to Sync to. If the version re-read via Applier after
+	// acquiring the lock already matches it, On releases the lock and returns ErrAlreadyUpToDate. Leave at zero
+	// with a nil Applier to disable this check.
+	TargetVersion int
+	// Key identifies the advisory lock. Two listeners sharing the same Key serialize against each other. If
+	// zero, Namespace is hashed to derive the key.
+	Key int64
+	// Namespace is hashed (FNV-1a) into Key when Key is zero. Defaults to "godatabaseversioner" when empty.
+	Namespace string
+
+	lastKnownVersion int
+	conn             *sql.Conn
+}
+
+func (l *PostgresAdvisoryLockListener) key() int64 {
+	if 0 != l.Key {
+		return l.Key
+	}
+	return hashNamespace(l.Namespace)
+}
+
+func (l *PostgresAdvisoryLockListener) On(ctx context.Context, event Event) error {
+	switch event.Type {
+	case EventStart:
+		conn, err := l.DB.Conn(ctx)
+		if nil != err {
+			return fmt.Errorf("checking out connection for advisory lock: %w", err)
+		}
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, l.key()); nil != err {
+			_ = conn.Close()
+			return fmt.Errorf("acquiring advisory lock: %w", err)
+		}
+		l.conn = conn
+		if nil != l.Applier {
+			version, err := l.Applier.CurrentVersion(ctx)
+			if err != nil {
+				return fmt.Errorf("re-checking current version after acquiring advisory lock: %w", err)
+			}
+			l.lastKnownVersion = version
+			if version == l.TargetVersion {
+				if err := l.release(ctx); nil != err {
+					return err
+				}
+				return fmt.Errorf("%w", ErrAlreadyUpToDate)
+			}
+		}
+	case EventEnd, EventError, EventErrorDuringChange:
+		return l.release(ctx)
+	}
+	return nil
+}
+
+func (l *PostgresAdvisoryLockListener) release(ctx context.Context) error {
+	if nil == l.conn {
+		return nil
+	}
+	_, unlockErr := l.conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, l.key())
+	closeErr := l.conn.Close()
+	l.conn = nil
+	if nil != unlockErr {
+		return fmt.Errorf("releasing advisory lock: %w", unlockErr)
+	}
+	if nil != closeErr {
+		return fmt.Errorf("closing advisory lock connection: %w", closeErr)
+	}
+	return nil
+}
+
+// LastKnownVersion returns the version read right after the advisory lock was last acquired.
+func (l *PostgresAdvisoryLockListener) LastKnownVersion() int {
+	return l.lastKnownVersion
+}
+
+// MySQLAdvisoryLockListener is the MySQL/MariaDB equivalent of PostgresAdvisoryLockListener, using GET_LOCK and
+// RELEASE_LOCK named locks instead of postgres advisory locks.
+//
+// GET_LOCK/RELEASE_LOCK are tied to the session that acquired the lock, so like PostgresAdvisoryLockListener
+// this pins a dedicated *sql.Conn for the acquire/release pair instead of going through the *sql.DB pool.
+type MySQLAdvisoryLockListener struct {
+	DB *sql.DB
+	// Applier is used to re-read the current version once the lock is acquired.
+	Applier VersionApplier
+	// TargetVersion is the version the caller is about to Sync to. If the version re-read via Applier after
+	// acquiring the lock already matches it, On releases the lock and returns ErrAlreadyUpToDate. Leave at zero
+	// with a nil Applier to disable this check.
+	TargetVersion int
+	// Name identifies the named lock. Two listeners sharing the same Name serialize against each other.
+	// Defaults to "godatabaseversioner" when empty.
+	Name string
+	// Timeout is the number of seconds GET_LOCK waits for the lock before giving up. Defaults to 0 (no wait)
+	// when negative, matching MySQL's own default of not waiting.
+	Timeout int
+
+	lastKnownVersion int
+	conn             *sql.Conn
+}
+
+func (l *MySQLAdvisoryLockListener) name() string {
+	if "" == l.Name {
+		return defaultLockNamespace
+	}
+	return l.Name
+}
+
+func (l *MySQLAdvisoryLockListener) On(ctx context.Context, event Event) error {
+	switch event.Type {
+	case EventStart:
+		conn, err := l.DB.Conn(ctx)
+		if nil != err {
+			return fmt.Errorf("checking out connection for named lock: %w", err)
+		}
+		timeout := l.Timeout
+		if timeout < 0 {
+			timeout = 0
+		}
+		var acquired int
+		row := conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, ?)`, l.name(), timeout)
+		if err := row.Scan(&acquired); nil != err {
+			_ = conn.Close()
+			return fmt.Errorf("acquiring named lock: %w", err)
+		}
+		if 1 != acquired {
+			_ = conn.Close()
+			return fmt.Errorf("acquiring named lock '%s': timed out", l.name())
+		}
+		l.conn = conn
+		if nil != l.Applier {
+			version, err := l.Applier.CurrentVersion(ctx)
+			if err != nil {
+				return fmt.Errorf("re-checking current version after acquiring named lock: %w", err)
+			}
+			l.lastKnownVersion = version
+			if version == l.TargetVersion {
+				if err := l.release(ctx); nil != err {
+					return err
+				}
+				return fmt.Errorf("%w", ErrAlreadyUpToDate)
+			}
+		}
+	case EventEnd, EventError, EventErrorDuringChange:
+		return l.release(ctx)
+	}
+	return nil
+}
+
+func (l *MySQLAdvisoryLockListener) release(ctx context.Context) error {
+	if nil == l.conn {
+		return nil
+	}
+	_, unlockErr := l.conn.ExecContext(ctx, `SELECT RELEASE_LOCK(?)`, l.name())
+	closeErr := l.conn.Close()
+	l.conn = nil
+	if nil != unlockErr {
+		return fmt.Errorf("releasing named lock: %w", unlockErr)
+	}
+	if nil != closeErr {
+		return fmt.Errorf("closing named lock connection: %w", closeErr)
+	}
+	return nil
+}
+
+// LastKnownVersion returns the version read right after the named lock was last acquired.
+func (l *MySQLAdvisoryLockListener) LastKnownVersion() int {
+	return l.lastKnownVersion
+}
+
+// SQLServerAppLockListener is the SQL Server equivalent of PostgresAdvisoryLockListener, using
+// sp_getapplock/sp_releaseapplock application locks instead of postgres advisory locks.
+//
+// sp_getapplock with @LockOwner = 'Session' ties the lock to the connection that took it, so like
+// PostgresAdvisoryLockListener this pins a dedicated *sql.Conn for the acquire/release pair instead of going
+// through the *sql.DB pool.
+type SQLServerAppLockListener struct {
+	DB *sql.DB
+	// Applier is used to re-read the current version once the lock is acquired.
+	Applier VersionApplier
+	// TargetVersion is the version the caller is about to Sync to. If the version re-read via Applier after
+	// acquiring the lock already matches it, On releases the lock and returns ErrAlreadyUpToDate. Leave at zero
+	// with a nil Applier to disable this check.
+	TargetVersion int
+	// Resource identifies the application lock. Two listeners sharing the same Resource serialize against each
+	// other. Defaults to "godatabaseversioner" when empty.
+	Resource string
+
+	lastKnownVersion int
+	conn             *sql.Conn
+}
+
+func (l *SQLServerAppLockListener) resource() string {
+	if "" == l.Resource {
+		return defaultLockNamespace
+	}
+	return l.Resource
+}
+
+func (l *SQLServerAppLockListener) On(ctx context.Context, event Event) error {
+	switch event.Type {
+	case EventStart:
+		conn, err := l.DB.Conn(ctx)
+		if nil != err {
+			return fmt.Errorf("checking out connection for application lock: %w", err)
+		}
+		// sp_getapplock reports success/failure through its procedure return code, not a result set, so it
+		// has to be captured into a variable and selected back out rather than scanned directly.
+		var result int
+		row := conn.QueryRowContext(ctx, `DECLARE @result int;
+EXEC @result = sp_getapplock @Resource = @p1, @LockMode = 'Exclusive', @LockOwner = 'Session';
+SELECT @result;`, l.resource())
+		if err := row.Scan(&result); nil != err {
+			_ = conn.Close()
+			return fmt.Errorf("acquiring application lock: %w", err)
+		}
+		if result < 0 {
+			_ = conn.Close()
+			return fmt.Errorf("acquiring application lock '%s': sp_getapplock returned %d", l.resource(), result)
+		}
+		l.conn = conn
+		if nil != l.Applier {
+			version, err := l.Applier.CurrentVersion(ctx)
+			if err != nil {
+				return fmt.Errorf("re-checking current version after acquiring application lock: %w", err)
+			}
+			l.lastKnownVersion = version
+			if version == l.TargetVersion {
+				if err := l.release(ctx); nil != err {
+					return err
+				}
+				return fmt.Errorf("%w", ErrAlreadyUpToDate)
+			}
+		}
+	case EventEnd, EventError, EventErrorDuringChange:
+		return l.release(ctx)
+	}
+	return nil
+}
+
+func (l *SQLServerAppLockListener) release(ctx context.Context) error {
+	if nil == l.conn {
+		return nil
+	}
+	_, unlockErr := l.conn.ExecContext(ctx, `EXEC sp_releaseapplock @Resource = @p1, @LockOwner = 'Session'`, l.resource())
+	closeErr := l.conn.Close()
+	l.conn = nil
+	if nil != unlockErr {
+		return fmt.Errorf("releasing application lock: %w", unlockErr)
+	}
+	if nil != closeErr {
+		return fmt.Errorf("closing application lock connection: %w", closeErr)
+	}
+	return nil
+}
+
+// LastKnownVersion returns the version read right after the application lock was last acquired.
+func (l *SQLServerAppLockListener) LastKnownVersion() int {
+	return l.lastKnownVersion
+}
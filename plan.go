@@ -0,0 +1,58 @@
+package godatabaseversioner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Direction indicates whether a sync would upgrade or downgrade the structure.
+type Direction string
+
+const (
+	DirectionUpgrade   Direction = "upgrade"
+	DirectionDowngrade Direction = "downgrade"
+)
+
+// Plan describes what SyncContext would do for a given target version, without applying anything.
+type Plan struct {
+	// CurrentVersion is the version read from the applier at the time the plan was built.
+	CurrentVersion int
+	// TargetVersion is the version the plan was built for.
+	TargetVersion int
+	// Direction is DirectionUpgrade if applying the plan would upgrade the structure, DirectionDowngrade otherwise.
+	Direction Direction
+	// Versions is the ordered list of Version.Number() values that would be applied, in the order Sync would
+	// apply them.
+	Versions []int
+}
+
+// Plan returns a description of what SyncContext(ctx, targetVersion) would do, without applying anything.
+func (v Versioner) Plan(ctx context.Context, targetVersion int) (Plan, error) {
+	currentVersion, err := v.CurrentVersionContext(ctx)
+	if err != nil {
+		return Plan{}, fmt.Errorf("could not build plan: %w", err)
+	}
+
+	direction := DirectionUpgrade
+	if targetVersion < currentVersion {
+		direction = DirectionDowngrade
+	}
+
+	sort.Slice(v.Versions, func(i, j int) bool {
+		return v.Versions[i].Number() < v.Versions[j].Number()
+	})
+	versionsToApply := v.loadVersionsToApply(DirectionUpgrade == direction, currentVersion, targetVersion)
+
+	numbers := make([]int, 0, len(versionsToApply))
+	for _, version := range versionsToApply {
+		numbers = append(numbers, version.Number())
+	}
+
+	return Plan{
+		CurrentVersion: currentVersion,
+		TargetVersion:  targetVersion,
+		Direction:      direction,
+		Versions:       numbers,
+	}, nil
+}
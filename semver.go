@@ -0,0 +1,268 @@
+package godatabaseversioner
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// SemverVersion hold the scripts that will allow a structure version to be upgraded or downgraded, identified by
+// a semantic version instead of a plain integer. This lets migrations be tagged along application releases
+// (v1.4.2) instead of forcing a single, ever-growing counter.
+type SemverVersion interface {
+	// Number should return the semantic version for these modifications.
+	Number() *semver.Version
+	// Upgrade will hold the script use to upgrade database version
+	Upgrade(ctx context.Context) error
+	// Rollback will allow to rollback the database to a previous state
+	Rollback(ctx context.Context) error
+}
+
+// SemverVersionApplier is used to manage operations of SemverVersioner on a specific type of structure
+type SemverVersionApplier interface {
+	// CurrentVersion returns the current structure version, or nil if none has been applied yet
+	CurrentVersion(ctx context.Context) (*semver.Version, error)
+	// RecordUpgrade should record that version has just been applied, so CurrentVersion reports it.
+	RecordUpgrade(ctx context.Context, version *semver.Version) error
+	// RecordRollback should record that version has just been rolled back, so CurrentVersion stops reporting it
+	// as applied.
+	RecordRollback(ctx context.Context, version *semver.Version) error
+}
+
+// SemverEvent represents an event during a SemverVersioner sync
+type SemverEvent struct {
+	Type    EventType
+	Version SemverVersion
+	Error   error
+}
+
+// SemverListener allow to react to some event during a SemverVersioner sync
+type SemverListener interface {
+	// On should implement the logic to execute when an event is triggered
+	On(ctx context.Context, event SemverEvent) error
+}
+
+// SemverVersioner hold the logic behind upgrade/downgrade of a structure versioned with semantic versions,
+// mirroring Versioner's event/listener/applier machinery.
+type SemverVersioner struct {
+	Applier  SemverVersionApplier
+	Versions []SemverVersion
+	Listener SemverListener
+	// DryRun, when true, makes SyncContext emit every EventBefore*/EventAfter* event without actually calling
+	// Upgrade, Rollback, RecordUpgrade or RecordRollback.
+	DryRun bool
+}
+
+// NewSemverVersioner will create a semver versioner without any listener
+func NewSemverVersioner(applier SemverVersionApplier, versions []SemverVersion) *SemverVersioner {
+	return &SemverVersioner{
+		Applier:  applier,
+		Versions: versions,
+		Listener: noOpSemverListener{},
+	}
+}
+
+type noOpSemverListener struct{}
+
+func (noOpSemverListener) On(_ context.Context, _ SemverEvent) error {
+	return nil
+}
+
+// CurrentVersionContext will return current structure version without applying any modification. It returns
+// "0.0.0" if the applier reports no version has been applied yet.
+func (v SemverVersioner) CurrentVersionContext(ctx context.Context) (*semver.Version, error) {
+	current, err := v.Applier.CurrentVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if nil == current {
+		return semver.New(0, 0, 0, "", ""), nil
+	}
+	return current, nil
+}
+
+// LastVersion will return the highest applicable version, based on assigned versions
+func (v SemverVersioner) LastVersion() *semver.Version {
+	last := semver.New(0, 0, 0, "", "")
+	for _, version := range v.Versions {
+		if version.Number().GreaterThan(last) {
+			last = version.Number()
+		}
+	}
+	return last
+}
+
+// UpgradeToLastContext will upgrade the structure to the highest available version
+func (v SemverVersioner) UpgradeToLastContext(ctx context.Context) error {
+	return v.SyncContext(ctx, v.LastVersion())
+}
+
+// SyncContext will sync the structure to the specified semantic version
+func (v SemverVersioner) SyncContext(ctx context.Context, targetVersion *semver.Version) error {
+	if err := v.Listener.On(ctx, SemverEvent{EventStart, nil, nil}); nil != err {
+		return fmt.Errorf("event %s: %w", EventStart, err)
+	}
+	currentVersion, err := v.CurrentVersionContext(ctx)
+	if err != nil {
+		if eventErr := v.Listener.On(ctx, SemverEvent{EventError, nil, err}); nil != eventErr {
+			return fmt.Errorf("could not sync (event error: %s): %w", eventErr.Error(), err)
+		}
+		return fmt.Errorf("could not sync: %w", err)
+	}
+
+	if currentVersion.Equal(targetVersion) {
+		if err := v.Listener.On(ctx, SemverEvent{EventEnd, nil, nil}); nil != err {
+			return fmt.Errorf("event %s: %w", EventEnd, err)
+		}
+		return nil
+	}
+
+	sort.Slice(v.Versions, func(i, j int) bool {
+		return v.Versions[i].Number().LessThan(v.Versions[j].Number())
+	})
+
+	upgrade := targetVersion.GreaterThan(currentVersion)
+	versionsToApply := v.loadVersionsToApply(upgrade, currentVersion, targetVersion)
+
+	if err := v.Listener.On(ctx, SemverEvent{EventBeforeSync, nil, nil}); nil != err {
+		return fmt.Errorf("event %s: %w", EventBeforeSync, err)
+	}
+	for _, version := range versionsToApply {
+		if err := v.Listener.On(ctx, SemverEvent{EventBeforeChange, version, nil}); nil != err {
+			return fmt.Errorf("event %s: %w", EventBeforeChange, err)
+		}
+		if !v.DryRun {
+			if upgrade {
+				if err := version.Upgrade(ctx); nil != err {
+					if eventErr := v.Listener.On(ctx, SemverEvent{EventErrorDuringChange, version, err}); nil != eventErr {
+						return fmt.Errorf("upgrade to version %s (event error: %s): %w", version.Number(), eventErr.Error(), err)
+					}
+					return fmt.Errorf("upgrade to version %s: %w", version.Number(), err)
+				}
+			} else {
+				if err := version.Rollback(ctx); nil != err {
+					if eventErr := v.Listener.On(ctx, SemverEvent{EventErrorDuringChange, version, err}); nil != eventErr {
+						return fmt.Errorf("rollback to version %s (event error: %s): %w", version.Number(), eventErr.Error(), err)
+					}
+					return fmt.Errorf("rollback to version %s: %w", version.Number(), err)
+				}
+			}
+			if upgrade {
+				if err := v.Applier.RecordUpgrade(ctx, version.Number()); nil != err {
+					if eventErr := v.Listener.On(ctx, SemverEvent{EventErrorDuringChange, version, err}); nil != eventErr {
+						return fmt.Errorf("sync version to %s (event error: %s): %w", version.Number(), eventErr.Error(), err)
+					}
+					return fmt.Errorf("sync version to %s: %w", version.Number(), err)
+				}
+			} else {
+				if err := v.Applier.RecordRollback(ctx, version.Number()); nil != err {
+					if eventErr := v.Listener.On(ctx, SemverEvent{EventErrorDuringChange, version, err}); nil != eventErr {
+						return fmt.Errorf("sync version to %s (event error: %s): %w", version.Number(), eventErr.Error(), err)
+					}
+					return fmt.Errorf("sync version to %s: %w", version.Number(), err)
+				}
+			}
+		}
+		if err := v.Listener.On(ctx, SemverEvent{EventAfterChange, version, nil}); nil != err {
+			return fmt.Errorf("event %s: %w", EventAfterChange, err)
+		}
+	}
+	if err := v.Listener.On(ctx, SemverEvent{EventAfterSync, nil, nil}); nil != err {
+		return fmt.Errorf("event %s: %w", EventAfterSync, err)
+	}
+
+	if err := v.Listener.On(ctx, SemverEvent{EventEnd, nil, nil}); nil != err {
+		return fmt.Errorf("event %s: %w", EventEnd, err)
+	}
+	return nil
+}
+
+// loadVersionsToApply returns the versions an upgrade/downgrade would go through, in application order. An
+// upgrade applies every version in (currentVersion, targetVersion], including the target itself. A downgrade
+// rolls back every version in (targetVersion, currentVersion], including the current one, highest first.
+func (v SemverVersioner) loadVersionsToApply(upgrade bool, currentVersion, targetVersion *semver.Version) []SemverVersion {
+	toApply := make([]SemverVersion, 0)
+	for _, version := range v.Versions {
+		number := version.Number()
+		if upgrade {
+			isBetweenCurrentAndTargetVersions := number.GreaterThan(currentVersion) && (number.LessThan(targetVersion) || number.Equal(targetVersion))
+			if isBetweenCurrentAndTargetVersions {
+				toApply = append(toApply, version)
+			}
+		} else {
+			isBetweenCurrentAndTargetVersions := number.GreaterThan(targetVersion) && (number.LessThan(currentVersion) || number.Equal(currentVersion))
+			if isBetweenCurrentAndTargetVersions {
+				toApply = append([]SemverVersion{version}, toApply...)
+			}
+		}
+	}
+	return toApply
+}
+
+// IntVersion adapts an integer-numbered Version to the SemverVersion interface, by mapping its Number() to
+// semantic version "<n>.0.0". This lets existing integer-based Version implementations be reused unchanged
+// inside a SemverVersioner.
+type IntVersion struct {
+	Version
+}
+
+func (v IntVersion) Number() *semver.Version {
+	return semver.New(uint64(v.Version.Number()), 0, 0, "", "")
+}
+
+// SemverPostgresVersionApplier is a SemverVersionApplier that works with a postgres database, storing the
+// version as TEXT and picking the most recently applied one by modificationTime, matching the style used by
+// projects that key their migrations by semantic version (v0.7.0, v0.9.0, ...) instead of a plain integer.
+type SemverPostgresVersionApplier struct {
+	DB *sql.DB
+	// TableName is the name of the table holding the version history. Defaults to "version" if empty.
+	TableName string
+}
+
+func (a SemverPostgresVersionApplier) tableName() string {
+	if "" == a.TableName {
+		return "version"
+	}
+	return a.TableName
+}
+
+func (a SemverPostgresVersionApplier) CurrentVersion(ctx context.Context) (*semver.Version, error) {
+	query := fmt.Sprintf(`SELECT version FROM %s ORDER BY modificationTime DESC LIMIT 1`, a.tableName())
+	row := a.DB.QueryRowContext(ctx, query)
+	var raw string
+	if err := row.Scan(&raw); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		if strings.Contains(err.Error(), "not exist") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not get current version: %w", err)
+	}
+	version, err := semver.NewVersion(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing stored version '%s': %w", raw, err)
+	}
+	return version, nil
+}
+
+func (a SemverPostgresVersionApplier) RecordUpgrade(ctx context.Context, version *semver.Version) error {
+	query := fmt.Sprintf(`INSERT INTO %s(id, version, modificationTime) VALUES (gen_random_uuid(), $1, now())`, a.tableName())
+	if _, err := a.DB.ExecContext(ctx, query, version.String()); nil != err {
+		return fmt.Errorf("could not record upgrade to version %s: %w", version.String(), err)
+	}
+	return nil
+}
+
+func (a SemverPostgresVersionApplier) RecordRollback(ctx context.Context, version *semver.Version) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE version = $1`, a.tableName())
+	if _, err := a.DB.ExecContext(ctx, query, version.String()); nil != err {
+		return fmt.Errorf("could not record rollback of version %s: %w", version.String(), err)
+	}
+	return nil
+}
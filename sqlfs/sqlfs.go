@@ -0,0 +1,145 @@
+// Package sqlfs discovers godatabaseversioner.Version implementations from .sql files stored in an fs.FS
+// (typically an embed.FS), so callers don't have to hand-write a Go type per migration.
+package sqlfs
+
+import (
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/normegil/godatabaseversioner"
+)
+
+var pairedFileNamePattern = regexp.MustCompile(`^(\d+)_.*\.(up|down)\.sql$`)
+var singleFileNamePattern = regexp.MustCompile(`^(\d+)_.*\.sql$`)
+
+const (
+	directiveUp   = "-- +migration Up"
+	directiveDown = "-- +migration Down"
+)
+
+// Option configures LoadVersions.
+type Option func(*options)
+
+type options struct {
+	transactional bool
+}
+
+// WithTransaction makes every loaded version run its SQL inside its own transaction, committing on success and
+// rolling back on error. Don't combine it with godatabaseversioner.TransactionalChangesListener on the same DB,
+// since that would open a second, nested transaction per version.
+func WithTransaction() Option {
+	return func(o *options) {
+		o.transactional = true
+	}
+}
+
+// LoadVersions walks fsys for .sql migration files and returns them as godatabaseversioner.Version
+// implementations that run their statements against db.
+//
+// Two file layouts are supported:
+//   - a pair of files per version, named "<number>_<name>.up.sql" and "<number>_<name>.down.sql"
+//   - a single file per version, named "<number>_<name>.sql", with the upgrade and rollback statements
+//     separated by "-- +migration Up" / "-- +migration Down" marker comments
+//
+// Statements within a file are split on ';', except inside '$$'-quoted bodies (as used by PL/pgSQL function and
+// procedure definitions), which are kept intact.
+func LoadVersions(fsys fs.FS, db *sql.DB, opts ...Option) ([]godatabaseversioner.Version, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	versions := map[int]*fileVersion{}
+	err := fs.WalkDir(fsys, ".", func(filePath string, d fs.DirEntry, err error) error {
+		if nil != err {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+
+		if matches := pairedFileNamePattern.FindStringSubmatch(name); nil != matches {
+			number, convErr := strconv.Atoi(matches[1])
+			if nil != convErr {
+				return fmt.Errorf("parsing version number from '%s': %w", name, convErr)
+			}
+			content, readErr := fs.ReadFile(fsys, filePath)
+			if nil != readErr {
+				return fmt.Errorf("reading '%s': %w", filePath, readErr)
+			}
+			version := versions[number]
+			if nil == version {
+				version = &fileVersion{number: number, db: db, transactional: o.transactional}
+				versions[number] = version
+			}
+			if "up" == matches[2] {
+				version.upStatements = splitStatements(string(content))
+			} else {
+				version.downStatements = splitStatements(string(content))
+			}
+			return nil
+		}
+
+		if matches := singleFileNamePattern.FindStringSubmatch(name); nil != matches {
+			number, convErr := strconv.Atoi(matches[1])
+			if nil != convErr {
+				return fmt.Errorf("parsing version number from '%s': %w", name, convErr)
+			}
+			content, readErr := fs.ReadFile(fsys, filePath)
+			if nil != readErr {
+				return fmt.Errorf("reading '%s': %w", filePath, readErr)
+			}
+			up, down, splitErr := splitDirectives(string(content))
+			if nil != splitErr {
+				return fmt.Errorf("parsing '%s': %w", filePath, splitErr)
+			}
+			versions[number] = &fileVersion{
+				number:         number,
+				db:             db,
+				transactional:  o.transactional,
+				upStatements:   splitStatements(up),
+				downStatements: splitStatements(down),
+			}
+			return nil
+		}
+
+		return nil
+	})
+	if nil != err {
+		return nil, fmt.Errorf("walking migration filesystem: %w", err)
+	}
+
+	numbers := make([]int, 0, len(versions))
+	for number := range versions {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	result := make([]godatabaseversioner.Version, 0, len(numbers))
+	for _, number := range numbers {
+		result = append(result, versions[number])
+	}
+	return result, nil
+}
+
+func splitDirectives(content string) (up string, down string, err error) {
+	upIdx := strings.Index(content, directiveUp)
+	downIdx := strings.Index(content, directiveDown)
+	if -1 == upIdx || -1 == downIdx {
+		return "", "", fmt.Errorf("missing '%s'/'%s' marker comments", directiveUp, directiveDown)
+	}
+	if upIdx < downIdx {
+		up = content[upIdx+len(directiveUp) : downIdx]
+		down = content[downIdx+len(directiveDown):]
+	} else {
+		down = content[downIdx+len(directiveDown) : upIdx]
+		up = content[upIdx+len(directiveUp):]
+	}
+	return up, down, nil
+}
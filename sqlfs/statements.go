@@ -0,0 +1,36 @@
+package sqlfs
+
+import "strings"
+
+// splitStatements splits sql on ';' statement terminators, while treating anything between a pair of '$$'
+// markers (as used by PL/pgSQL function/procedure bodies) as opaque, so semicolons inside those bodies don't
+// split the statement in two. Empty statements (blank lines, trailing terminators) are dropped.
+func splitStatements(sql string) []string {
+	var statements []string
+	var current strings.Builder
+	insideDollarQuote := false
+
+	runes := []rune(sql)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if '$' == r && i+1 < len(runes) && '$' == runes[i+1] {
+			insideDollarQuote = !insideDollarQuote
+			current.WriteRune(r)
+			current.WriteRune(runes[i+1])
+			i++
+			continue
+		}
+		if ';' == r && !insideDollarQuote {
+			if statement := strings.TrimSpace(current.String()); "" != statement {
+				statements = append(statements, statement)
+			}
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if statement := strings.TrimSpace(current.String()); "" != statement {
+		statements = append(statements, statement)
+	}
+	return statements
+}
@@ -0,0 +1,56 @@
+package sqlfs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// fileVersion is a godatabaseversioner.Version backed by SQL statements loaded from one or two .sql files.
+type fileVersion struct {
+	number         int
+	db             *sql.DB
+	transactional  bool
+	upStatements   []string
+	downStatements []string
+}
+
+func (v *fileVersion) Number() int {
+	return v.number
+}
+
+func (v *fileVersion) Upgrade(ctx context.Context) error {
+	return v.run(ctx, v.upStatements)
+}
+
+func (v *fileVersion) Rollback(ctx context.Context) error {
+	return v.run(ctx, v.downStatements)
+}
+
+func (v *fileVersion) run(ctx context.Context, statements []string) error {
+	if !v.transactional {
+		for _, statement := range statements {
+			if _, err := v.db.ExecContext(ctx, statement); nil != err {
+				return fmt.Errorf("executing statement for version %d: %w", v.number, err)
+			}
+		}
+		return nil
+	}
+
+	tx, err := v.db.BeginTx(ctx, nil)
+	if nil != err {
+		return fmt.Errorf("starting transaction for version %d: %w", v.number, err)
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); nil != err {
+			if rollbackErr := tx.Rollback(); nil != rollbackErr {
+				return fmt.Errorf("executing statement for version %d: %w (rollback also failed: %s)", v.number, err, rollbackErr.Error())
+			}
+			return fmt.Errorf("executing statement for version %d: %w", v.number, err)
+		}
+	}
+	if err := tx.Commit(); nil != err {
+		return fmt.Errorf("committing transaction for version %d: %w", v.number, err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package godatabaseversioner
+
+import (
+	"context"
+	"fmt"
+)
+
+// ErrVersionMismatch reports that the database is not at the expected version.
+type ErrVersionMismatch struct {
+	Have int
+	Want int
+}
+
+func (e ErrVersionMismatch) Error() string {
+	return fmt.Sprintf("version mismatch: have %d, want %d", e.Have, e.Want)
+}
+
+// ErrVersionAhead reports that the database is at a version more recent than expected, meaning the running
+// application is older than the schema it's connected to.
+type ErrVersionAhead struct {
+	ErrVersionMismatch
+}
+
+func (e ErrVersionAhead) Error() string {
+	return fmt.Sprintf("version ahead: have %d, want %d", e.Have, e.Want)
+}
+
+// ErrVersionBehind reports that the database is at a version older than expected, meaning the schema hasn't
+// been migrated to match the running application yet.
+type ErrVersionBehind struct {
+	ErrVersionMismatch
+}
+
+func (e ErrVersionBehind) Error() string {
+	return fmt.Sprintf("version behind: have %d, want %d", e.Have, e.Want)
+}
+
+// Validate reads the current version via the applier and returns an error without running any upgrade or
+// rollback. It returns ErrVersionAhead if the database is more recent than expected, ErrVersionBehind if it's
+// older, and nil if it matches. This lets an application fail fast at startup when deployed against a database
+// that isn't at the version it expects.
+func (v Versioner) Validate(ctx context.Context, expected int) error {
+	current, err := v.CurrentVersionContext(ctx)
+	if err != nil {
+		return fmt.Errorf("could not validate version: %w", err)
+	}
+
+	var validationErr error
+	switch {
+	case current == expected:
+		validationErr = nil
+	case current > expected:
+		validationErr = ErrVersionAhead{ErrVersionMismatch{Have: current, Want: expected}}
+	default:
+		validationErr = ErrVersionBehind{ErrVersionMismatch{Have: current, Want: expected}}
+	}
+
+	if err := v.Listener.On(ctx, Event{EventValidate, nil, validationErr}); nil != err {
+		return fmt.Errorf("event %s: %w", EventValidate, err)
+	}
+
+	return validationErr
+}
+
+// ValidateLatest checks that the database is at the highest version known to this Versioner.
+func (v Versioner) ValidateLatest(ctx context.Context) error {
+	return v.Validate(ctx, v.LastVersion())
+}
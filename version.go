@@ -1,6 +1,7 @@
 package godatabaseversioner
 
 import (
+	"context"
 	"fmt"
 	"sort"
 )
@@ -17,6 +18,7 @@ const (
 	EventAfterChange       EventType = "after-change"
 	EventErrorDuringChange EventType = "error-during-change"
 	EventError             EventType = "error"
+	EventValidate          EventType = "validate"
 )
 
 // Event represents an event during version syncing
@@ -28,8 +30,9 @@ type Event struct {
 
 // Listener allow to react to some event during initialization
 type Listener interface {
-	// On should implement the logic to execute when an event is triggered
-	On(event Event) error
+	// On should implement the logic to execute when an event is triggered. ctx allows the caller to propagate
+	// cancellation, deadlines and tracing spans down to the listener.
+	On(ctx context.Context, event Event) error
 }
 
 // Version hold the scripts that will allow a structure version to be upgraded or downgraded
@@ -38,18 +41,23 @@ type Version interface {
 	// successive number series, but the highest the number, the more up-to-date a structure is. Start at 0, 0 being an
 	// empty state (without version)
 	Number() int
-	// Upgrade will hold the script use to upgrade database version
-	Upgrade() error
-	// Rollback will allow to rollback the database to a previous state
-	Rollback() error
+	// Upgrade will hold the script use to upgrade database version. ctx allows the caller to propagate
+	// cancellation, deadlines and tracing spans to the underlying database calls.
+	Upgrade(ctx context.Context) error
+	// Rollback will allow to rollback the database to a previous state. ctx allows the caller to propagate
+	// cancellation, deadlines and tracing spans to the underlying database calls.
+	Rollback(ctx context.Context) error
 }
 
 // VersionerQuerier is used to manage operations of Versioner on a specific type of structure
 type VersionApplier interface {
 	// CurrentVersion return current structure version
-	CurrentVersion() (int, error)
-	// SyncVersion should upgrade stored version for concerned structure
-	SyncVersion(versionNb int) error
+	CurrentVersion(ctx context.Context) (int, error)
+	// RecordUpgrade should record that versionNb has just been applied, so CurrentVersion reports it.
+	RecordUpgrade(ctx context.Context, versionNb int) error
+	// RecordRollback should record that versionNb has just been rolled back, so CurrentVersion stops reporting
+	// it as applied.
+	RecordRollback(ctx context.Context, versionNb int) error
 }
 
 // Versioner hold the logic behind upgrade/downgrade of the managed structure
@@ -57,6 +65,10 @@ type Versioner struct {
 	Applier  VersionApplier
 	Versions []Version
 	Listener Listener
+	// DryRun, when true, makes SyncContext emit every EventBefore*/EventAfter* event without actually calling
+	// Upgrade, Rollback, RecordUpgrade or RecordRollback, so a listener (e.g. ZerologListener) can print the
+	// intended plan without mutating the database.
+	DryRun bool
 }
 
 // NewVersioner will create a versioner without any listener
@@ -68,9 +80,16 @@ func NewVersioner(applier VersionApplier, Versions []Version) *Versioner {
 	}
 }
 
-// Current version will return current structure version without applying any modification
+// CurrentVersion will return current structure version without applying any modification
+//
+// Deprecated: use CurrentVersionContext instead.
 func (v Versioner) CurrentVersion() (int, error) {
-	return v.Applier.CurrentVersion()
+	return v.CurrentVersionContext(context.Background())
+}
+
+// CurrentVersionContext will return current structure version without applying any modification
+func (v Versioner) CurrentVersionContext(ctx context.Context) (int, error) {
+	return v.Applier.CurrentVersion(ctx)
 }
 
 // Last version will return last applicable version, based on assigned versions
@@ -86,25 +105,41 @@ func (v Versioner) LastVersion() int {
 }
 
 // UpgradeToLast will upgrade the structure to the last available version
+//
+// Deprecated: use UpgradeToLastContext instead.
 func (v Versioner) UpgradeToLast() error {
-	return v.Sync(v.LastVersion())
+	return v.UpgradeToLastContext(context.Background())
+}
+
+// UpgradeToLastContext will upgrade the structure to the last available version
+func (v Versioner) UpgradeToLastContext(ctx context.Context) error {
+	return v.SyncContext(ctx, v.LastVersion())
 }
 
 // Sync will sync the structure to specified version
+//
+// Deprecated: use SyncContext instead.
 func (v Versioner) Sync(targetVersion int) error {
-	if err := v.Listener.On(Event{EventStart, nil, nil}); nil != err {
+	return v.SyncContext(context.Background(), targetVersion)
+}
+
+// SyncContext will sync the structure to specified version. ctx is propagated to the applier, the versions
+// being applied, and the listener, so callers can cancel a long-running migration, apply per-call timeouts, or
+// attach tracing spans.
+func (v Versioner) SyncContext(ctx context.Context, targetVersion int) error {
+	if err := v.Listener.On(ctx, Event{EventStart, nil, nil}); nil != err {
 		return fmt.Errorf("event %s: %w", EventStart, err)
 	}
-	currentVersion, err := v.CurrentVersion()
+	currentVersion, err := v.CurrentVersionContext(ctx)
 	if err != nil {
-		if eventErr := v.Listener.On(Event{EventError, nil, err}); nil != eventErr {
+		if eventErr := v.Listener.On(ctx, Event{EventError, nil, err}); nil != eventErr {
 			return fmt.Errorf("could not sync (event error: %s): %w", eventErr.Error(), err)
 		}
 		return fmt.Errorf("could not sync: %w", err)
 	}
 
 	if currentVersion == targetVersion {
-		if err := v.Listener.On(Event{EventEnd, nil, nil}); nil != err {
+		if err := v.Listener.On(ctx, Event{EventEnd, nil, nil}); nil != err {
 			return fmt.Errorf("event %s: %w", EventEnd, err)
 		}
 		return nil
@@ -120,58 +155,72 @@ func (v Versioner) Sync(targetVersion int) error {
 	}
 	versionsToApply := v.loadVersionsToApply(upgrade, currentVersion, targetVersion)
 
-	if err := v.Listener.On(Event{EventBeforeSync, nil, nil}); nil != err {
+	if err := v.Listener.On(ctx, Event{EventBeforeSync, nil, nil}); nil != err {
 		return fmt.Errorf("event %s: %w", EventBeforeSync, err)
 	}
 	for _, version := range versionsToApply {
-		if err := v.Listener.On(Event{EventBeforeChange, version, nil}); nil != err {
+		if err := v.Listener.On(ctx, Event{EventBeforeChange, version, nil}); nil != err {
 			return fmt.Errorf("event %s: %w", EventBeforeChange, err)
 		}
-		if upgrade {
-			if err := version.Upgrade(); nil != err {
-				if eventErr := v.Listener.On(Event{EventErrorDuringChange, version, err}); nil != eventErr {
-					return fmt.Errorf("upgrade to version %d (event error: %s): %w", version.Number(), eventErr.Error(), err)
+		if !v.DryRun {
+			if upgrade {
+				if err := version.Upgrade(ctx); nil != err {
+					if eventErr := v.Listener.On(ctx, Event{EventErrorDuringChange, version, err}); nil != eventErr {
+						return fmt.Errorf("upgrade to version %d (event error: %s): %w", version.Number(), eventErr.Error(), err)
+					}
+					return fmt.Errorf("upgrade to version %d: %w", version.Number(), err)
 				}
-				return fmt.Errorf("upgrade to version %d: %w", version.Number(), err)
-			}
-		} else {
-			if err := version.Rollback(); nil != err {
-				if eventErr := v.Listener.On(Event{EventErrorDuringChange, version, err}); nil != eventErr {
-					return fmt.Errorf("rollback to version %d (event error: %s): %w", version.Number(), eventErr.Error(), err)
+			} else {
+				if err := version.Rollback(ctx); nil != err {
+					if eventErr := v.Listener.On(ctx, Event{EventErrorDuringChange, version, err}); nil != eventErr {
+						return fmt.Errorf("rollback to version %d (event error: %s): %w", version.Number(), eventErr.Error(), err)
+					}
+					return fmt.Errorf("rollback to version %d: %w", version.Number(), err)
 				}
-				return fmt.Errorf("rollback to version %d: %w", version.Number(), err)
 			}
-		}
-		if err := v.Applier.SyncVersion(version.Number()); nil != err {
-			if eventErr := v.Listener.On(Event{EventErrorDuringChange, version, err}); nil != eventErr {
-				return fmt.Errorf("sync version to %d (event error: %s): %w", version.Number(), eventErr.Error(), err)
+			if upgrade {
+				if err := v.Applier.RecordUpgrade(ctx, version.Number()); nil != err {
+					if eventErr := v.Listener.On(ctx, Event{EventErrorDuringChange, version, err}); nil != eventErr {
+						return fmt.Errorf("sync version to %d (event error: %s): %w", version.Number(), eventErr.Error(), err)
+					}
+					return fmt.Errorf("sync version to %d: %w", version.Number(), err)
+				}
+			} else {
+				if err := v.Applier.RecordRollback(ctx, version.Number()); nil != err {
+					if eventErr := v.Listener.On(ctx, Event{EventErrorDuringChange, version, err}); nil != eventErr {
+						return fmt.Errorf("sync version to %d (event error: %s): %w", version.Number(), eventErr.Error(), err)
+					}
+					return fmt.Errorf("sync version to %d: %w", version.Number(), err)
+				}
 			}
-			return fmt.Errorf("sync version to %d: %w", version.Number(), err)
 		}
-		if err := v.Listener.On(Event{EventAfterChange, version, nil}); nil != err {
+		if err := v.Listener.On(ctx, Event{EventAfterChange, version, nil}); nil != err {
 			return fmt.Errorf("event %s: %w", EventAfterChange, err)
 		}
 	}
-	if err := v.Listener.On(Event{EventAfterSync, nil, nil}); nil != err {
+	if err := v.Listener.On(ctx, Event{EventAfterSync, nil, nil}); nil != err {
 		return fmt.Errorf("event %s: %w", EventAfterSync, err)
 	}
 
-	if err := v.Listener.On(Event{EventEnd, nil, nil}); nil != err {
+	if err := v.Listener.On(ctx, Event{EventEnd, nil, nil}); nil != err {
 		return fmt.Errorf("event %s: %w", EventEnd, err)
 	}
 	return nil
 }
 
+// loadVersionsToApply returns the versions an upgrade/downgrade would go through, in application order. An
+// upgrade applies every version in (currentVersion, targetVersion], including the target itself. A downgrade
+// rolls back every version in (targetVersion, currentVersion], including the current one, highest first.
 func (v Versioner) loadVersionsToApply(upgrade bool, currentVersion int, targetVersion int) []Version {
 	toApply := make([]Version, 0)
 	for _, version := range v.Versions {
 		if upgrade {
-			isBetweenCurrentAndTargetVersions := version.Number() > currentVersion && version.Number() < targetVersion
+			isBetweenCurrentAndTargetVersions := version.Number() > currentVersion && version.Number() <= targetVersion
 			if isBetweenCurrentAndTargetVersions {
 				toApply = append(toApply, version)
 			}
 		} else {
-			isBetweenCurrentAndTargetVersions := version.Number() < currentVersion && version.Number() > targetVersion
+			isBetweenCurrentAndTargetVersions := version.Number() > targetVersion && version.Number() <= currentVersion
 			if isBetweenCurrentAndTargetVersions {
 				toApply = append([]Version{version}, toApply...)
 			}
@@ -0,0 +1,216 @@
+package godatabaseversioner
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fakeVersion is a Version whose Upgrade/Rollback do nothing and always succeed, so tests can assert on
+// ordering (through the listener and applier) without touching a real database.
+type fakeVersion struct {
+	number int
+}
+
+func (f fakeVersion) Number() int { return f.number }
+
+func (f fakeVersion) Upgrade(_ context.Context) error { return nil }
+
+func (f fakeVersion) Rollback(_ context.Context) error { return nil }
+
+// fakeApplier is a VersionApplier backed by an in-memory slice of applied version numbers, standing in for a
+// real database's version table.
+type fakeApplier struct {
+	current int
+	applied []int
+}
+
+func (a *fakeApplier) CurrentVersion(_ context.Context) (int, error) {
+	return a.current, nil
+}
+
+func (a *fakeApplier) RecordUpgrade(_ context.Context, versionNb int) error {
+	a.applied = append(a.applied, versionNb)
+	a.current = versionNb
+	return nil
+}
+
+func (a *fakeApplier) RecordRollback(_ context.Context, versionNb int) error {
+	a.applied = append(a.applied, -versionNb)
+	a.current = versionNb - 1
+	return nil
+}
+
+// recordingListener records the version number carried by every EventBeforeChange, in the order the events
+// were raised, so tests can assert on the exact application order instead of just the final version.
+type recordingListener struct {
+	beforeChange []int
+}
+
+func (l *recordingListener) On(_ context.Context, event Event) error {
+	if EventBeforeChange == event.Type {
+		l.beforeChange = append(l.beforeChange, event.Version.Number())
+	}
+	return nil
+}
+
+func versions(numbers ...int) []Version {
+	out := make([]Version, 0, len(numbers))
+	for _, n := range numbers {
+		out = append(out, fakeVersion{number: n})
+	}
+	return out
+}
+
+func TestVersioner_SyncContext_Upgrade(t *testing.T) {
+	applier := &fakeApplier{current: 0}
+	listener := &recordingListener{}
+	v := Versioner{
+		Applier:  applier,
+		Versions: versions(3, 1, 2),
+		Listener: listener,
+	}
+
+	if err := v.SyncContext(context.Background(), 3); nil != err {
+		t.Fatalf("SyncContext: %v", err)
+	}
+
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(want, listener.beforeChange) {
+		t.Fatalf("versions applied in wrong order: got %v, want %v", listener.beforeChange, want)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(want, applier.applied) {
+		t.Fatalf("RecordUpgrade calls: got %v, want %v", applier.applied, want)
+	}
+}
+
+func TestVersioner_SyncContext_UpgradePartial(t *testing.T) {
+	applier := &fakeApplier{current: 1}
+	listener := &recordingListener{}
+	v := Versioner{
+		Applier:  applier,
+		Versions: versions(1, 2, 3, 4),
+		Listener: listener,
+	}
+
+	if err := v.SyncContext(context.Background(), 3); nil != err {
+		t.Fatalf("SyncContext: %v", err)
+	}
+
+	if want := []int{2, 3}; !reflect.DeepEqual(want, listener.beforeChange) {
+		t.Fatalf("versions applied in wrong order: got %v, want %v (version 1 already applied, 4 beyond target)", listener.beforeChange, want)
+	}
+}
+
+func TestVersioner_SyncContext_Downgrade(t *testing.T) {
+	applier := &fakeApplier{current: 3}
+	listener := &recordingListener{}
+	v := Versioner{
+		Applier:  applier,
+		Versions: versions(1, 2, 3),
+		Listener: listener,
+	}
+
+	if err := v.SyncContext(context.Background(), 1); nil != err {
+		t.Fatalf("SyncContext: %v", err)
+	}
+
+	if want := []int{3, 2}; !reflect.DeepEqual(want, listener.beforeChange) {
+		t.Fatalf("versions rolled back in wrong order: got %v, want %v (highest first, target excluded)", listener.beforeChange, want)
+	}
+	if want := []int{-3, -2}; !reflect.DeepEqual(want, applier.applied) {
+		t.Fatalf("RecordRollback calls: got %v, want %v", applier.applied, want)
+	}
+}
+
+func TestVersioner_SyncContext_AlreadyAtTarget(t *testing.T) {
+	applier := &fakeApplier{current: 2}
+	listener := &recordingListener{}
+	v := Versioner{
+		Applier:  applier,
+		Versions: versions(1, 2),
+		Listener: listener,
+	}
+
+	if err := v.SyncContext(context.Background(), 2); nil != err {
+		t.Fatalf("SyncContext: %v", err)
+	}
+
+	if 0 != len(listener.beforeChange) {
+		t.Fatalf("expected no versions applied when already at target, got %v", listener.beforeChange)
+	}
+	if 0 != len(applier.applied) {
+		t.Fatalf("expected no applier calls when already at target, got %v", applier.applied)
+	}
+}
+
+func TestVersioner_SyncContext_DryRunDoesNotRecord(t *testing.T) {
+	applier := &fakeApplier{current: 0}
+	listener := &recordingListener{}
+	v := Versioner{
+		Applier:  applier,
+		Versions: versions(1, 2),
+		Listener: listener,
+		DryRun:   true,
+	}
+
+	if err := v.SyncContext(context.Background(), 2); nil != err {
+		t.Fatalf("SyncContext: %v", err)
+	}
+
+	if want := []int{1, 2}; !reflect.DeepEqual(want, listener.beforeChange) {
+		t.Fatalf("expected dry run to still raise before-change events for %v, got %v", want, listener.beforeChange)
+	}
+	if 0 != len(applier.applied) {
+		t.Fatalf("expected dry run not to call RecordUpgrade/RecordRollback, got %v", applier.applied)
+	}
+}
+
+func TestVersioner_loadVersionsToApply(t *testing.T) {
+	v := Versioner{Versions: versions(1, 2, 3, 4, 5)}
+
+	upgrade := v.loadVersionsToApply(true, 1, 4)
+	if want := []int{2, 3, 4}; !reflect.DeepEqual(want, numbersOf(upgrade)) {
+		t.Fatalf("upgrade (1 -> 4): got %v, want %v", numbersOf(upgrade), want)
+	}
+
+	downgrade := v.loadVersionsToApply(false, 4, 1)
+	if want := []int{4, 3, 2}; !reflect.DeepEqual(want, numbersOf(downgrade)) {
+		t.Fatalf("downgrade (4 -> 1): got %v, want %v", numbersOf(downgrade), want)
+	}
+
+	if none := v.loadVersionsToApply(true, 3, 3); 0 != len(none) {
+		t.Fatalf("expected no versions when current equals target, got %v", numbersOf(none))
+	}
+}
+
+func numbersOf(vs []Version) []int {
+	out := make([]int, 0, len(vs))
+	for _, version := range vs {
+		out = append(out, version.Number())
+	}
+	return out
+}
+
+func TestVersioner_SyncContext_PropagatesCurrentVersionError(t *testing.T) {
+	errBoom := errors.New("boom")
+	v := Versioner{
+		Applier:  erroringApplier{err: errBoom},
+		Versions: versions(1),
+		Listener: NoOpListener{},
+	}
+
+	if err := v.SyncContext(context.Background(), 1); nil == err || !errors.Is(err, errBoom) {
+		t.Fatalf("expected wrapped errBoom, got %v", err)
+	}
+}
+
+type erroringApplier struct {
+	err error
+}
+
+func (a erroringApplier) CurrentVersion(_ context.Context) (int, error) { return -1, a.err }
+
+func (a erroringApplier) RecordUpgrade(_ context.Context, _ int) error { return nil }
+
+func (a erroringApplier) RecordRollback(_ context.Context, _ int) error { return nil }